@@ -118,6 +118,14 @@ type MergeOptions struct {
 	Overwrite bool
 	// Nillify removes keys from the target map if the value is nil in the source map
 	Nillify bool
+	// Strategy, when set, switches Merge into strategic merge mode (see WithStrategy).
+	Strategy *Strategy
+	// SkipEmpty prevents a zero-value source leaf from overwriting a non-zero destination value.
+	SkipEmpty bool
+	// ExplicitClear makes Merge recognize the Clear sentinel as a source value, clearing the destination key even when SkipEmpty would otherwise have left it alone.
+	ExplicitClear bool
+	// Append concatenates a source slice onto a same-typed destination slice instead of replacing or skipping it.
+	Append bool
 }
 
 type MergeOption func(*MergeOptions)
@@ -136,34 +144,188 @@ func WithNillify() MergeOption {
 	}
 }
 
-// Merge deep merges the source map into the target map. Regardless of options, Mappings will be merged recursively. Slices are treated as any single value and are not combined.
+// WithSkipEmpty sets the SkipEmpty option to true, so that with
+// WithOverwrite also set, a zero-value source leaf (an empty string,
+// zero number, or empty slice/Mapping) no longer overwrites a non-zero
+// destination value. Without WithOverwrite this has no effect, since a
+// present destination key is already left alone.
+//
+// Interaction with other options: WithNillify is unaffected, since nil
+// is handled before SkipEmpty is considered. WithExplicitClear overrides
+// WithSkipEmpty for source values that are the Clear sentinel.
+func WithSkipEmpty() MergeOption {
+	return func(o *MergeOptions) {
+		o.SkipEmpty = true
+	}
+}
+
+// WithExplicitClear makes Merge recognize Clear as a source value: when
+// present, it always clears the destination key, even under
+// WithSkipEmpty and regardless of WithOverwrite. It gives callers a way
+// to distinguish "this key is absent from the source" (left alone) from
+// "this key should be explicitly cleared" without relying on an
+// ordinary zero value, which WithSkipEmpty would otherwise ignore.
+func WithExplicitClear() MergeOption {
+	return func(o *MergeOptions) {
+		o.ExplicitClear = true
+	}
+}
+
+// WithAppend sets the Append option to true, so a source value that is a
+// slice of the same type as the existing destination value (for example
+// []string or []Mapping, as opposed to the []any Merge otherwise treats
+// strategically) is concatenated onto it instead of replacing it.
+func WithAppend() MergeOption {
+	return func(o *MergeOptions) {
+		o.Append = true
+	}
+}
+
+// Clear is a sentinel source value that, combined with
+// WithExplicitClear, tells Merge to remove the corresponding destination
+// key.
+var Clear = clearSentinel{}
+
+type clearSentinel struct{}
+
+// isZeroValue reports whether v is the zero value of its type, treating
+// an empty (but non-nil) string, slice or map the same as a nil one.
+func isZeroValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// mayOverwrite reports whether Merge should write v into m[k], honoring
+// Overwrite and, when set, SkipEmpty.
+func mayOverwrite(m Mapping, k string, v any, options MergeOptions) bool {
+	if !m.HasKey(k) {
+		return true
+	}
+	if !options.Overwrite {
+		return false
+	}
+	if options.SkipEmpty && isZeroValue(v) && !isZeroValue(m[k]) {
+		return false
+	}
+	return true
+}
+
+// appendSlice returns dest concatenated with src and true if both are
+// slices of the same concrete type (for example []string or []Mapping).
+// It reports false for anything else. mergeInto never calls it for
+// untyped []any source slices, which it routes through mergeList instead.
+func appendSlice(dest, src any) (any, bool) {
+	if dest == nil || src == nil {
+		return nil, false
+	}
+	dv := reflect.ValueOf(dest)
+	sv := reflect.ValueOf(src)
+	if dv.Kind() != reflect.Slice || sv.Kind() != reflect.Slice || dv.Type() != sv.Type() {
+		return nil, false
+	}
+	result := reflect.MakeSlice(dv.Type(), 0, dv.Len()+sv.Len())
+	result = reflect.AppendSlice(result, dv)
+	result = reflect.AppendSlice(result, sv)
+	return result.Interface(), true
+}
+
+// Merge deep merges the source map into the target map. Regardless of options, Mappings will be merged recursively. Slices are treated as any single value and are not combined, unless a Strategy given via WithStrategy says otherwise or WithAppend is set.
 func (m Mapping) Merge(source Mapping, opts ...MergeOption) {
 	options := MergeOptions{}
 	for _, opt := range opts {
 		opt(&options)
 	}
+	m.mergeInto(source, nil, options)
+}
+
+// MergeAll merges each of sources into m in order, so later sources take
+// precedence over earlier ones wherever opts (for example WithOverwrite)
+// allows it. It lets callers layer several sources - defaults, a file,
+// environment variables, flags - onto m in one call instead of calling
+// Merge repeatedly.
+func (m Mapping) MergeAll(sources []Mapping, opts ...MergeOption) {
+	for _, source := range sources {
+		m.Merge(source, opts...)
+	}
+}
+
+// Merged returns a new Mapping built by merging sources, in order, onto a
+// fresh empty Mapping; sources themselves are left untouched. It is the
+// immutable counterpart to MergeAll.
+func Merged(sources []Mapping, opts ...MergeOption) Mapping {
+	result := Mapping{}
+	result.MergeAll(sources, opts...)
+	return result
+}
+
+// mergeInto is the path-aware core of Merge. path is the sequence of keys
+// that led from the original Merge call down to source, used to look up
+// per-path behavior in options.Strategy and to recognize $patch directives.
+func (m Mapping) mergeInto(source Mapping, path []string, options MergeOptions) {
 	for k, v := range source {
 		switch v := v.(type) {
 		case Mapping:
+			if directive, _ := v[patchDirectiveKey].(string); directive != "" && options.Strategy != nil {
+				switch directive {
+				case patchDelete:
+					delete(m, k)
+					continue
+				case patchReplace:
+					replacement := withoutPatchKey(v)
+					m[k] = replacement.Dup()
+					continue
+				}
+			}
 			if !m.HasKey(k) {
 				m[k] = v.Dup()
 			} else if m.HasMapping(k) {
-				m.DigMapping(k).Merge(v, opts...)
-			} else if options.Overwrite {
+				m.DigMapping(k).mergeInto(v, appendPath(path, k), options)
+			} else if mayOverwrite(m, k, v, options) {
 				m[k] = v.Dup()
 			}
 		case nil:
 			if options.Nillify {
 				m[k] = nil
 			}
+		case []any:
+			m.mergeList(k, v, path, options)
 		default:
-			if !m.HasKey(k) || options.Overwrite {
+			if options.ExplicitClear {
+				if _, ok := v.(clearSentinel); ok {
+					delete(m, k)
+					continue
+				}
+			}
+			if options.Append {
+				if appended, ok := appendSlice(m[k], v); ok {
+					m[k] = deepCopy(appended)
+					continue
+				}
+			}
+			if mayOverwrite(m, k, v, options) {
 				m[k] = deepCopy(v)
 			}
 		}
 	}
 }
 
+// appendPath returns a new path slice with k appended, without mutating
+// path's backing array (siblings in the same mergeInto call share path).
+func appendPath(path []string, k string) []string {
+	newPath := make([]string, len(path)+1)
+	copy(newPath, path)
+	newPath[len(path)] = k
+	return newPath
+}
+
 // deepCopy performs a deep copy of the value using reflection
 func deepCopy(value any) any {
 	if value == nil {
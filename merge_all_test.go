@@ -0,0 +1,44 @@
+package dig_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/dig"
+)
+
+func TestMergeAll(t *testing.T) {
+	defaults := dig.Mapping{"foo": "default", "bar": "default"}
+	file := dig.Mapping{"foo": "file"}
+	env := dig.Mapping{"foo": "env", "baz": "env"}
+
+	t.Run("later sources win with overwrite", func(t *testing.T) {
+		m := dig.Mapping{}
+		m.MergeAll([]dig.Mapping{defaults, file, env}, dig.WithOverwrite())
+
+		mustEqualString(t, "env", m.DigString("foo"))
+		mustEqualString(t, "default", m.DigString("bar"))
+		mustEqualString(t, "env", m.DigString("baz"))
+	})
+
+	t.Run("first source wins without overwrite", func(t *testing.T) {
+		m := dig.Mapping{}
+		m.MergeAll([]dig.Mapping{defaults, file, env})
+
+		mustEqualString(t, "default", m.DigString("foo"))
+	})
+}
+
+func TestMerged(t *testing.T) {
+	defaults := dig.Mapping{"foo": "default", "bar": "default"}
+	file := dig.Mapping{"foo": "file"}
+
+	result := dig.Merged([]dig.Mapping{defaults, file}, dig.WithOverwrite())
+
+	mustEqualString(t, "file", result.DigString("foo"))
+	mustEqualString(t, "default", result.DigString("bar"))
+
+	t.Run("sources are left untouched", func(t *testing.T) {
+		mustEqualString(t, "default", defaults.DigString("foo"))
+		mustEqualString(t, "file", file.DigString("foo"))
+	})
+}
@@ -0,0 +1,83 @@
+package dig_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/dig"
+)
+
+func TestMergeWithSkipEmpty(t *testing.T) {
+	t.Run("empty string does not overwrite a populated destination", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.Merge(dig.Mapping{"foo": ""}, dig.WithOverwrite(), dig.WithSkipEmpty())
+		mustEqualString(t, "bar", m.DigString("foo"))
+	})
+
+	t.Run("empty []string does not overwrite a populated destination", func(t *testing.T) {
+		m := dig.Mapping{"foo": []string{"a"}}
+		m.Merge(dig.Mapping{"foo": []string{}}, dig.WithOverwrite(), dig.WithSkipEmpty())
+		mustEqual(t, 1, len(m.Dig("foo").([]string)))
+	})
+
+	t.Run("empty []any does not overwrite a populated destination", func(t *testing.T) {
+		m := dig.Mapping{"foo": []any{"a"}}
+		m.Merge(dig.Mapping{"foo": []any{}}, dig.WithOverwrite(), dig.WithSkipEmpty())
+		mustEqual(t, 1, len(m.Dig("foo").([]any)))
+	})
+
+	t.Run("empty Mapping does not overwrite a populated destination", func(t *testing.T) {
+		m := dig.Mapping{"foo": dig.Mapping{"a": "b"}}
+		m.Merge(dig.Mapping{"foo": dig.Mapping{}}, dig.WithOverwrite(), dig.WithSkipEmpty())
+		mustEqual(t, 1, len(m.Dig("foo").(dig.Mapping)))
+	})
+
+	t.Run("zero number does not overwrite a populated destination", func(t *testing.T) {
+		m := dig.Mapping{"foo": 5}
+		m.Merge(dig.Mapping{"foo": 0}, dig.WithOverwrite(), dig.WithSkipEmpty())
+		mustEqual(t, 5, m.Dig("foo"))
+	})
+
+	t.Run("non-empty value still sets a previously-empty destination", func(t *testing.T) {
+		m := dig.Mapping{"foo": ""}
+		m.Merge(dig.Mapping{"foo": "bar"}, dig.WithOverwrite(), dig.WithSkipEmpty())
+		mustEqualString(t, "bar", m.DigString("foo"))
+	})
+
+	t.Run("non-empty value still overwrites as usual", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.Merge(dig.Mapping{"foo": "baz"}, dig.WithOverwrite(), dig.WithSkipEmpty())
+		mustEqualString(t, "baz", m.DigString("foo"))
+	})
+
+	t.Run("without WithOverwrite, SkipEmpty has no extra effect", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.Merge(dig.Mapping{"foo": "baz"}, dig.WithSkipEmpty())
+		mustEqualString(t, "bar", m.DigString("foo"))
+	})
+}
+
+func TestMergeWithExplicitClear(t *testing.T) {
+	t.Run("Clear removes the destination key even under SkipEmpty", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.Merge(dig.Mapping{"foo": dig.Clear}, dig.WithOverwrite(), dig.WithSkipEmpty(), dig.WithExplicitClear())
+		mustEqual(t, false, m.HasKey("foo"))
+	})
+
+	t.Run("Clear removes the destination key without Overwrite", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.Merge(dig.Mapping{"foo": dig.Clear}, dig.WithExplicitClear())
+		mustEqual(t, false, m.HasKey("foo"))
+	})
+
+	t.Run("an ordinary empty string is still just skipped under SkipEmpty", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.Merge(dig.Mapping{"foo": ""}, dig.WithOverwrite(), dig.WithSkipEmpty(), dig.WithExplicitClear())
+		mustEqualString(t, "bar", m.DigString("foo"))
+	})
+
+	t.Run("without WithExplicitClear, Clear is merged in as an ordinary value", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.Merge(dig.Mapping{"foo": dig.Clear}, dig.WithOverwrite())
+		mustEqual(t, dig.Clear, m.Dig("foo"))
+	})
+}
@@ -0,0 +1,318 @@
+package dig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonPatchOp is a single RFC 6902 JSON Patch operation.
+type jsonPatchOp struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch document to m and returns
+// the result as a new Mapping; m itself is left untouched. Supported
+// operations are add, remove, replace, move, copy and test, with
+// JSON-pointer paths that may traverse both Mapping values and []any
+// slices. Other slice types, such as []string, cannot be indexed into:
+// an operation whose path goes through one returns an error.
+func (m Mapping) ApplyJSONPatch(patch []byte) (Mapping, error) {
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("dig: invalid JSON patch: %w", err)
+	}
+
+	var root any = m.Dup()
+	for _, op := range ops {
+		var err error
+		root, err = applyPatchOp(root, op)
+		if err != nil {
+			return nil, fmt.Errorf("dig: json patch op %q %q: %w", op.Op, op.Path, err)
+		}
+	}
+
+	result, ok := root.(Mapping)
+	if !ok {
+		return nil, fmt.Errorf("dig: json patch result is not a mapping, got %T", root)
+	}
+	return result, nil
+}
+
+func applyPatchOp(root any, op jsonPatchOp) (any, error) {
+	path := splitPointer(op.Path)
+
+	switch op.Op {
+	case "test":
+		actual, err := pointerGet(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(actual, cleanUpValue(op.Value)) {
+			return nil, fmt.Errorf("test failed: value does not match")
+		}
+		return root, nil
+	case "add":
+		return pointerSet(root, path, cleanUpValue(op.Value), true)
+	case "replace":
+		return pointerSet(root, path, cleanUpValue(op.Value), false)
+	case "remove":
+		return pointerRemove(root, path)
+	case "move":
+		from := splitPointer(op.From)
+		value, err := pointerGet(root, from)
+		if err != nil {
+			return nil, err
+		}
+		root, err = pointerRemove(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return pointerSet(root, path, value, true)
+	case "copy":
+		from := splitPointer(op.From)
+		value, err := pointerGet(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return pointerSet(root, path, deepCopy(value), true)
+	default:
+		return nil, fmt.Errorf("unsupported op %q", op.Op)
+	}
+}
+
+// splitPointer splits an RFC 6901 JSON pointer into its unescaped tokens.
+func splitPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, tok := range tokens {
+		tok = strings.ReplaceAll(tok, "~1", "/")
+		tok = strings.ReplaceAll(tok, "~0", "~")
+		tokens[i] = tok
+	}
+	return tokens
+}
+
+// sliceIndex resolves a JSON pointer token against a slice of length n,
+// returning the index it refers to. atEnd is true for the "-" token or an
+// index equal to n, both of which denote the position just past the last
+// element (valid for add, not for get/remove/replace).
+func sliceIndex(tok string, n int) (idx int, atEnd bool, err error) {
+	if tok == "-" {
+		return n, true, nil
+	}
+	idx, err = strconv.Atoi(tok)
+	if err != nil || idx < 0 || idx > n {
+		return 0, false, fmt.Errorf("invalid array index %q", tok)
+	}
+	return idx, idx == n, nil
+}
+
+func pointerGet(root any, path []string) (any, error) {
+	cur := root
+	for _, tok := range path {
+		switch c := cur.(type) {
+		case Mapping:
+			v, ok := c[tok]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found", tok)
+			}
+			cur = v
+		case []any:
+			idx, atEnd, err := sliceIndex(tok, len(c))
+			if err != nil {
+				return nil, err
+			}
+			if atEnd {
+				return nil, fmt.Errorf("array index %q out of range", tok)
+			}
+			cur = c[idx]
+		default:
+			return nil, fmt.Errorf("cannot index into %T with %q", cur, tok)
+		}
+	}
+	return cur, nil
+}
+
+func pointerRemove(root any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the document root")
+	}
+	return pointerDescend(root, path, func(parent any, tok string) (any, error) {
+		switch p := parent.(type) {
+		case Mapping:
+			if _, ok := p[tok]; !ok {
+				return nil, fmt.Errorf("key %q not found", tok)
+			}
+			delete(p, tok)
+			return p, nil
+		case []any:
+			idx, atEnd, err := sliceIndex(tok, len(p))
+			if err != nil || atEnd {
+				return nil, fmt.Errorf("array index %q out of range", tok)
+			}
+			return append(p[:idx:idx], p[idx+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot index into %T with %q", parent, tok)
+		}
+	})
+}
+
+func pointerSet(root any, path []string, value any, insert bool) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return pointerDescend(root, path, func(parent any, tok string) (any, error) {
+		switch p := parent.(type) {
+		case Mapping:
+			p[tok] = value
+			return p, nil
+		case []any:
+			idx, atEnd, err := sliceIndex(tok, len(p))
+			if err != nil {
+				return nil, err
+			}
+			if insert {
+				if atEnd {
+					return append(p, value), nil
+				}
+				result := make([]any, 0, len(p)+1)
+				result = append(result, p[:idx]...)
+				result = append(result, value)
+				result = append(result, p[idx:]...)
+				return result, nil
+			}
+			if atEnd {
+				return nil, fmt.Errorf("array index %q out of range", tok)
+			}
+			result := append([]any(nil), p...)
+			result[idx] = value
+			return result, nil
+		default:
+			return nil, fmt.Errorf("cannot index into %T with %q", parent, tok)
+		}
+	})
+}
+
+// pointerDescend walks container down to the parent of path's last token
+// and applies leaf to it, threading the (possibly new, for slices)
+// container value back up through every ancestor it passed through.
+func pointerDescend(container any, path []string, leaf func(parent any, tok string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return leaf(container, path[0])
+	}
+
+	tok, rest := path[0], path[1:]
+	switch c := container.(type) {
+	case Mapping:
+		child, ok := c[tok]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found", tok)
+		}
+		newChild, err := pointerDescend(child, rest, leaf)
+		if err != nil {
+			return nil, err
+		}
+		c[tok] = newChild
+		return c, nil
+	case []any:
+		idx, atEnd, err := sliceIndex(tok, len(c))
+		if err != nil || atEnd {
+			return nil, fmt.Errorf("array index %q out of range", tok)
+		}
+		newChild, err := pointerDescend(c[idx], rest, leaf)
+		if err != nil {
+			return nil, err
+		}
+		c[idx] = newChild
+		return c, nil
+	default:
+		return nil, fmt.Errorf("cannot index into %T with %q", container, tok)
+	}
+}
+
+// ApplyMergePatch applies an RFC 7396 JSON Merge Patch to m and returns
+// the result as a new Mapping; m itself is left untouched. A null value
+// in the patch deletes the corresponding key; any other value replaces
+// it, recursing into nested objects.
+func (m Mapping) ApplyMergePatch(patch []byte) (Mapping, error) {
+	var decoded any
+	if err := json.Unmarshal(patch, &decoded); err != nil {
+		return nil, fmt.Errorf("dig: invalid merge patch: %w", err)
+	}
+
+	patchMapping, ok := cleanUpValue(decoded).(Mapping)
+	if !ok {
+		return nil, fmt.Errorf("dig: merge patch must be a JSON object at the top level")
+	}
+
+	result := m.Dup()
+	mergePatchInto(result, patchMapping)
+	return result, nil
+}
+
+func mergePatchInto(target, patch Mapping) {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		if pv, ok := v.(Mapping); ok {
+			tv, ok := target[k].(Mapping)
+			if !ok {
+				tv = Mapping{}
+			}
+			mergePatchInto(tv, pv)
+			target[k] = tv
+			continue
+		}
+		target[k] = deepCopy(v)
+	}
+}
+
+// Diff compares a and b and returns an RFC 7396 JSON Merge Patch that,
+// applied to a via ApplyMergePatch, produces b.
+func Diff(a, b Mapping) ([]byte, error) {
+	patch := diffMapping(a, b)
+	out, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("dig: failed to marshal diff: %w", err)
+	}
+	return out, nil
+}
+
+func diffMapping(a, b Mapping) Mapping {
+	patch := Mapping{}
+	for k, bv := range b {
+		av, ok := a[k]
+		if !ok {
+			patch[k] = bv
+			continue
+		}
+		if reflect.DeepEqual(av, bv) {
+			continue
+		}
+		avm, aok := av.(Mapping)
+		bvm, bok := bv.(Mapping)
+		if aok && bok {
+			if sub := diffMapping(avm, bvm); len(sub) > 0 {
+				patch[k] = sub
+			}
+			continue
+		}
+		patch[k] = bv
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	return patch
+}
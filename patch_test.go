@@ -0,0 +1,182 @@
+package dig_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/dig"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	t.Run("add a new key", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		result, err := m.ApplyJSONPatch([]byte(`[{"op":"add","path":"/baz","value":"qux"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqualString(t, "qux", result.DigString("baz"))
+		mustEqualString(t, "", m.DigString("baz")) // original untouched
+	})
+
+	t.Run("replace a nested value", func(t *testing.T) {
+		m := dig.Mapping{"foo": dig.Mapping{"bar": "old"}}
+		result, err := m.ApplyJSONPatch([]byte(`[{"op":"replace","path":"/foo/bar","value":"new"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqualString(t, "new", result.DigString("foo", "bar"))
+	})
+
+	t.Run("remove a key", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar", "baz": "qux"}
+		result, err := m.ApplyJSONPatch([]byte(`[{"op":"remove","path":"/foo"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqual(t, false, result.HasKey("foo"))
+		mustEqualString(t, "qux", result.DigString("baz"))
+	})
+
+	t.Run("append to an array with -", func(t *testing.T) {
+		m := dig.Mapping{"list": []any{"a", "b"}}
+		result, err := m.ApplyJSONPatch([]byte(`[{"op":"add","path":"/list/-","value":"c"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		list := result.Dig("list").([]any)
+		mustEqual(t, 3, len(list))
+		mustEqualString(t, "c", list[2].(string))
+	})
+
+	t.Run("insert into an array at an index", func(t *testing.T) {
+		m := dig.Mapping{"list": []any{"a", "c"}}
+		result, err := m.ApplyJSONPatch([]byte(`[{"op":"add","path":"/list/1","value":"b"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		list := result.Dig("list").([]any)
+		mustEqual(t, 3, len(list))
+		mustEqualString(t, "b", list[1].(string))
+		mustEqualString(t, "c", list[2].(string))
+	})
+
+	t.Run("move a value", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		result, err := m.ApplyJSONPatch([]byte(`[{"op":"move","from":"/foo","path":"/baz"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqual(t, false, result.HasKey("foo"))
+		mustEqualString(t, "bar", result.DigString("baz"))
+	})
+
+	t.Run("copy a value", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		result, err := m.ApplyJSONPatch([]byte(`[{"op":"copy","from":"/foo","path":"/baz"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqualString(t, "bar", result.DigString("foo"))
+		mustEqualString(t, "bar", result.DigString("baz"))
+	})
+
+	t.Run("test passes", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		_, err := m.ApplyJSONPatch([]byte(`[{"op":"test","path":"/foo","value":"bar"}]`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("test fails", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		_, err := m.ApplyJSONPatch([]byte(`[{"op":"test","path":"/foo","value":"nope"}]`))
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("remove of a missing key fails", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		_, err := m.ApplyJSONPatch([]byte(`[{"op":"remove","path":"/missing"}]`))
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	t.Run("replaces and adds keys", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar", "baz": "qux"}
+		result, err := m.ApplyMergePatch([]byte(`{"foo":"new","extra":"value"}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqualString(t, "new", result.DigString("foo"))
+		mustEqualString(t, "qux", result.DigString("baz"))
+		mustEqualString(t, "value", result.DigString("extra"))
+	})
+
+	t.Run("null deletes a key", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar", "baz": "qux"}
+		result, err := m.ApplyMergePatch([]byte(`{"foo":null}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqual(t, false, result.HasKey("foo"))
+		mustEqualString(t, "qux", result.DigString("baz"))
+	})
+
+	t.Run("recurses into nested objects", func(t *testing.T) {
+		m := dig.Mapping{"nested": dig.Mapping{"a": "1", "b": "2"}}
+		result, err := m.ApplyMergePatch([]byte(`{"nested":{"a":"new"}}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqualString(t, "new", result.DigString("nested", "a"))
+		mustEqualString(t, "2", result.DigString("nested", "b"))
+	})
+
+	t.Run("non-object replaces the target wholesale", func(t *testing.T) {
+		m := dig.Mapping{"nested": dig.Mapping{"a": "1"}}
+		result, err := m.ApplyMergePatch([]byte(`{"nested":"scalar"}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mustEqualString(t, "scalar", result.DigString("nested"))
+	})
+}
+
+func TestDiff(t *testing.T) {
+	a := dig.Mapping{
+		"foo": "bar",
+		"baz": "qux",
+		"nested": dig.Mapping{
+			"a": "1",
+			"b": "2",
+		},
+	}
+	b := dig.Mapping{
+		"foo": "new",
+		"nested": dig.Mapping{
+			"a": "1",
+			"b": "new",
+		},
+		"added": "value",
+	}
+
+	patchBytes, err := dig.Diff(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := a.ApplyMergePatch(patchBytes)
+	if err != nil {
+		t.Fatalf("unexpected error applying diff: %v", err)
+	}
+
+	mustEqualString(t, "new", result.DigString("foo"))
+	mustEqual(t, false, result.HasKey("baz"))
+	mustEqualString(t, "1", result.DigString("nested", "a"))
+	mustEqualString(t, "new", result.DigString("nested", "b"))
+	mustEqualString(t, "value", result.DigString("added"))
+}
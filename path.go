@@ -0,0 +1,253 @@
+package dig
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// defaultPathDelimiter is the delimiter DigPath, SetPath and DeletePath use
+// to split a path string into keys.
+const defaultPathDelimiter = "."
+
+// DigPath is like Dig but takes a single delimited path string instead of
+// variadic keys, for example "servers.0.hosts.primary". A numeric segment
+// indexes into a []any or other slice value; any other segment indexes
+// into a nested Mapping. A literal delimiter can be included in a segment
+// by escaping it with a backslash, e.g. `DigPath("a\\.b.c")` digs "a.b"
+// then "c".
+func (m Mapping) DigPath(path string) any {
+	return digPathValue(m, splitPath(path, defaultPathDelimiter))
+}
+
+// DigPathString is like DigPath but returns the value as a string.
+func (m Mapping) DigPathString(path string) string {
+	s, _ := m.DigPath(path).(string)
+	return s
+}
+
+// SetPath sets the value at path, creating missing Mapping branches (and
+// overwriting other non-Mapping branches) along the way, the same way
+// DigMapping does for variadic keys. A slice branch is kept and indexed
+// only when the segment that reaches into it is numeric; otherwise it is
+// overwritten too.
+func (m Mapping) SetPath(path string, value any) {
+	tokens := splitPath(path, defaultPathDelimiter)
+	if len(tokens) == 0 {
+		return
+	}
+	setPathInto(m, tokens, value)
+}
+
+// DeletePath removes the value at path. It is a no-op if any segment of
+// the path doesn't exist.
+func (m Mapping) DeletePath(path string) {
+	tokens := splitPath(path, defaultPathDelimiter)
+	if len(tokens) == 0 {
+		return
+	}
+	deletePathInto(m, tokens)
+}
+
+// PathOptions configure an Accessor created with NewWithOptions.
+type PathOptions struct {
+	// Delimiter separates keys in a path string. Defaults to ".".
+	Delimiter string
+}
+
+// PathOption is used to configure an Accessor.
+type PathOption func(*PathOptions)
+
+// WithKeyDelimiter sets the delimiter an Accessor uses to split path
+// strings into keys, for users whose keys themselves contain ".".
+func WithKeyDelimiter(delimiter string) PathOption {
+	return func(o *PathOptions) {
+		o.Delimiter = delimiter
+	}
+}
+
+// Accessor digs into Mappings using a configurable key delimiter instead
+// of DigPath's hardcoded ".".
+type Accessor struct {
+	delimiter string
+}
+
+// NewWithOptions creates an Accessor configured via opts, for example
+// dig.NewWithOptions(dig.WithKeyDelimiter("::")).
+func NewWithOptions(opts ...PathOption) *Accessor {
+	options := PathOptions{Delimiter: defaultPathDelimiter}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Delimiter == "" {
+		options.Delimiter = defaultPathDelimiter
+	}
+	return &Accessor{delimiter: options.Delimiter}
+}
+
+// DigPath is like Mapping.DigPath but splits path on the Accessor's
+// configured delimiter.
+func (a *Accessor) DigPath(m Mapping, path string) any {
+	return digPathValue(m, splitPath(path, a.delimiter))
+}
+
+// DigPathString is like DigPath but returns the value as a string.
+func (a *Accessor) DigPathString(m Mapping, path string) string {
+	s, _ := a.DigPath(m, path).(string)
+	return s
+}
+
+// SetPath is like Mapping.SetPath but splits path on the Accessor's
+// configured delimiter.
+func (a *Accessor) SetPath(m Mapping, path string, value any) {
+	tokens := splitPath(path, a.delimiter)
+	if len(tokens) == 0 {
+		return
+	}
+	setPathInto(m, tokens, value)
+}
+
+// DeletePath is like Mapping.DeletePath but splits path on the Accessor's
+// configured delimiter.
+func (a *Accessor) DeletePath(m Mapping, path string) {
+	tokens := splitPath(path, a.delimiter)
+	if len(tokens) == 0 {
+		return
+	}
+	deletePathInto(m, tokens)
+}
+
+// splitPath splits path on delimiter, treating a backslash-escaped
+// delimiter as a literal part of the surrounding segment.
+func splitPath(path, delimiter string) []string {
+	if path == "" {
+		return nil
+	}
+
+	var tokens []string
+	var cur strings.Builder
+	for i := 0; i < len(path); {
+		if path[i] == '\\' && i+1 < len(path) {
+			cur.WriteByte(path[i+1])
+			i += 2
+			continue
+		}
+		if strings.HasPrefix(path[i:], delimiter) {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+			i += len(delimiter)
+			continue
+		}
+		cur.WriteByte(path[i])
+		i++
+	}
+	tokens = append(tokens, cur.String())
+	return tokens
+}
+
+// digPathValue walks v with tokens, indexing into Mapping values by key
+// and into []any/other slice values by integer index.
+func digPathValue(v any, tokens []string) any {
+	if len(tokens) == 0 {
+		return v
+	}
+
+	tok := tokens[0]
+	switch c := v.(type) {
+	case Mapping:
+		child, ok := c[tok]
+		if !ok {
+			return nil
+		}
+		return digPathValue(child, tokens[1:])
+	case []any:
+		idx, err := strconv.Atoi(tok)
+		if err != nil || idx < 0 || idx >= len(c) {
+			return nil
+		}
+		return digPathValue(c[idx], tokens[1:])
+	default:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return nil
+		}
+		idx, err := strconv.Atoi(tok)
+		if err != nil || idx < 0 || idx >= rv.Len() {
+			return nil
+		}
+		return digPathValue(rv.Index(idx).Interface(), tokens[1:])
+	}
+}
+
+// setPathInto sets value at tokens within container, vivifying missing
+// Mapping branches, and returns the (possibly new) container so callers
+// can write it back into their own parent. A numeric token indexes into
+// any slice value, in-range or not; an out-of-range index, or one whose
+// new value can't be assigned into a typed destination slice, is a no-op
+// rather than discarding the slice, mirroring deletePathInto.
+func setPathInto(container any, tokens []string, value any) any {
+	if len(tokens) == 0 {
+		return value
+	}
+
+	tok := tokens[0]
+	if idx, err := strconv.Atoi(tok); err == nil {
+		if s, ok := container.([]any); ok {
+			if idx >= 0 && idx < len(s) {
+				s[idx] = setPathInto(s[idx], tokens[1:], value)
+			}
+			return s
+		}
+		if rv := reflect.ValueOf(container); rv.Kind() == reflect.Slice {
+			if idx >= 0 && idx < rv.Len() {
+				elem := rv.Index(idx)
+				if nv := reflect.ValueOf(setPathInto(elem.Interface(), tokens[1:], value)); nv.IsValid() && nv.Type().AssignableTo(elem.Type()) {
+					elem.Set(nv)
+				}
+			}
+			return container
+		}
+	}
+
+	asMap, ok := container.(Mapping)
+	if !ok {
+		asMap = Mapping{}
+	}
+	asMap[tok] = setPathInto(asMap[tok], tokens[1:], value)
+	return asMap
+}
+
+// deletePathInto removes tokens' final segment from within container,
+// returning the (possibly new, for slices) container. Missing segments
+// are a no-op.
+func deletePathInto(container any, tokens []string) any {
+	if len(tokens) == 0 {
+		return container
+	}
+
+	tok := tokens[0]
+	if idx, err := strconv.Atoi(tok); err == nil {
+		if s, ok := container.([]any); ok && idx >= 0 && idx < len(s) {
+			if len(tokens) == 1 {
+				return append(s[:idx:idx], s[idx+1:]...)
+			}
+			s[idx] = deletePathInto(s[idx], tokens[1:])
+			return s
+		}
+	}
+
+	asMap, ok := container.(Mapping)
+	if !ok {
+		return container
+	}
+	if len(tokens) == 1 {
+		delete(asMap, tok)
+		return asMap
+	}
+	child, ok := asMap[tok]
+	if !ok {
+		return asMap
+	}
+	asMap[tok] = deletePathInto(child, tokens[1:])
+	return asMap
+}
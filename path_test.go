@@ -0,0 +1,131 @@
+package dig_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/dig"
+)
+
+func TestDigPath(t *testing.T) {
+	m := dig.Mapping{
+		"servers": []any{
+			dig.Mapping{"hosts": dig.Mapping{"primary": "a.example.com"}},
+			dig.Mapping{"hosts": dig.Mapping{"primary": "b.example.com"}},
+		},
+	}
+
+	t.Run("digs through a list index", func(t *testing.T) {
+		mustEqualString(t, "a.example.com", m.DigPathString("servers.0.hosts.primary"))
+		mustEqualString(t, "b.example.com", m.DigPathString("servers.1.hosts.primary"))
+	})
+
+	t.Run("out of range index returns nil", func(t *testing.T) {
+		mustBeNil(t, m.DigPath("servers.5.hosts.primary"))
+	})
+
+	t.Run("missing key returns nil", func(t *testing.T) {
+		mustBeNil(t, m.DigPath("servers.0.hosts.secondary"))
+	})
+
+	t.Run("escaped delimiter is part of the segment", func(t *testing.T) {
+		escaped := dig.Mapping{
+			"a.b": dig.Mapping{"c": "value"},
+		}
+		mustEqualString(t, "value", escaped.DigPathString(`a\.b.c`))
+	})
+}
+
+func TestSetPath(t *testing.T) {
+	t.Run("sets a deeply nested value, vivifying missing mappings", func(t *testing.T) {
+		m := dig.Mapping{}
+		m.SetPath("foo.bar.baz", "hello")
+		mustEqualString(t, "hello", m.DigPathString("foo.bar.baz"))
+	})
+
+	t.Run("sets a value inside an existing list element", func(t *testing.T) {
+		m := dig.Mapping{
+			"servers": []any{
+				dig.Mapping{"name": "a"},
+			},
+		}
+		m.SetPath("servers.0.name", "b")
+		mustEqualString(t, "b", m.DigPathString("servers.0.name"))
+	})
+
+	t.Run("sets an element of a typed slice in place", func(t *testing.T) {
+		m := dig.Mapping{"tags": []string{"a", "b"}}
+		m.SetPath("tags.0", "z")
+		tags := m.Dig("tags").([]string)
+		mustEqual(t, 2, len(tags))
+		mustEqualString(t, "z", tags[0])
+		mustEqualString(t, "b", tags[1])
+	})
+
+	t.Run("out of range index on a []any list is a no-op", func(t *testing.T) {
+		m := dig.Mapping{"list": []any{"a"}}
+		m.SetPath("list.5", "z")
+		list := m.Dig("list").([]any)
+		mustEqual(t, 1, len(list))
+		mustEqualString(t, "a", list[0].(string))
+	})
+
+	t.Run("out of range index on a typed slice is a no-op", func(t *testing.T) {
+		m := dig.Mapping{"tags": []string{"a"}}
+		m.SetPath("tags.5", "z")
+		tags := m.Dig("tags").([]string)
+		mustEqual(t, 1, len(tags))
+		mustEqualString(t, "a", tags[0])
+	})
+}
+
+func TestDeletePath(t *testing.T) {
+	t.Run("deletes a nested key", func(t *testing.T) {
+		m := dig.Mapping{
+			"foo": dig.Mapping{"bar": "baz"},
+		}
+		m.DeletePath("foo.bar")
+		foo := m.DigMapping("foo")
+		mustEqual(t, false, foo.HasKey("bar"))
+	})
+
+	t.Run("deletes a list element", func(t *testing.T) {
+		m := dig.Mapping{
+			"list": []any{"a", "b", "c"},
+		}
+		m.DeletePath("list.1")
+		list := m.Dig("list").([]any)
+		mustEqual(t, 2, len(list))
+		mustEqualString(t, "a", list[0].(string))
+		mustEqualString(t, "c", list[1].(string))
+	})
+
+	t.Run("missing path is a no-op", func(t *testing.T) {
+		m := dig.Mapping{"foo": "bar"}
+		m.DeletePath("missing.path")
+		mustEqualString(t, "bar", m.DigString("foo"))
+	})
+}
+
+func TestAccessorWithKeyDelimiter(t *testing.T) {
+	a := dig.NewWithOptions(dig.WithKeyDelimiter("::"))
+
+	m := dig.Mapping{
+		"a.b": dig.Mapping{
+			"c::d": "value",
+		},
+	}
+
+	t.Run("digs using the configured delimiter", func(t *testing.T) {
+		mustEqualString(t, "value", a.DigPathString(m, `a.b::c\:\:d`))
+	})
+
+	t.Run("sets using the configured delimiter", func(t *testing.T) {
+		a.SetPath(m, "a.b::e", "new")
+		mustEqualString(t, "new", a.DigPathString(m, "a.b::e"))
+	})
+
+	t.Run("deletes using the configured delimiter", func(t *testing.T) {
+		a.DeletePath(m, "a.b::e")
+		mustBeNil(t, a.DigPath(m, "a.b::e"))
+	})
+}
@@ -0,0 +1,193 @@
+package dig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// patchDirectiveKey is the key Merge looks for in a source Mapping or list
+// element to recognize a strategic merge patch directive, inspired by
+// Kubernetes' strategic merge patch.
+const patchDirectiveKey = "$patch"
+
+const (
+	// patchReplace forces the destination sub-tree or list element to be
+	// replaced wholesale instead of deep-merged.
+	patchReplace = "replace"
+	// patchDelete removes the destination key, or the matching list
+	// element, instead of merging.
+	patchDelete = "delete"
+)
+
+// ListStrategy controls how Merge combines a list found at a given path
+// when driven by a Strategy. The default, ListStrategyReplace, is the
+// pre-Strategy behavior: the source list replaces the destination list.
+type ListStrategy int
+
+const (
+	// ListStrategyReplace replaces the destination list with the source
+	// list, subject to WithOverwrite like any other existing value.
+	ListStrategyReplace ListStrategy = iota
+	// ListStrategyMerge merges the destination and source lists
+	// element-wise, matching dig.Mapping elements by the key configured
+	// for the path in Strategy.ListMergeKeys.
+	ListStrategyMerge
+	// ListStrategyMergeSet merges the destination and source lists as a
+	// deduplicated union of scalar values.
+	ListStrategyMergeSet
+)
+
+// Strategy configures Merge's strategic merge mode (see WithStrategy).
+// Paths are dot-joined key sequences relative to the Mapping Merge was
+// called on, for example "spec.containers". A path with no ListStrategy
+// entry, or a ListStrategyMerge path with no ListMergeKeys entry, falls
+// back to the default replace-the-slice behavior. ListStrategyMergeSet
+// paths need no ListMergeKeys entry.
+type Strategy struct {
+	// ListMergeKeys maps a path to the Mapping key used to match
+	// elements of a ListStrategyMerge list, e.g. "name".
+	ListMergeKeys map[string]string
+	// ListStrategy maps a path to the strategy used to combine the list
+	// found there.
+	ListStrategy map[string]ListStrategy
+}
+
+// WithStrategy switches Merge into strategic merge mode: lists are
+// combined according to s instead of always being replaced, and $patch
+// directives found in the source Mapping are honored. A $patch: replace
+// on a sub-mapping forces that sub-tree to be replaced rather than
+// deep-merged; $patch: delete removes the key from the destination; and,
+// for a ListStrategyMerge list, a source element of the form
+// {"$patch": "delete", "<mergeKey>": <value>} removes the matching
+// element from the destination list.
+func WithStrategy(s Strategy) MergeOption {
+	return func(o *MergeOptions) {
+		o.Strategy = &s
+	}
+}
+
+// withoutPatchKey returns v with the $patch directive key removed, copying
+// only when necessary.
+func withoutPatchKey(v Mapping) Mapping {
+	if _, ok := v[patchDirectiveKey]; !ok {
+		return v
+	}
+	cp := make(Mapping, len(v))
+	for k, val := range v {
+		if k == patchDirectiveKey {
+			continue
+		}
+		cp[k] = val
+	}
+	return cp
+}
+
+// mergeList merges a source list found at m[k] (path being the keys that
+// lead to m) according to options.Strategy, falling back to the classic
+// replace-if-missing-or-overwrite behavior when no strategy applies.
+func (m Mapping) mergeList(k string, source []any, path []string, options MergeOptions) {
+	if options.Strategy != nil {
+		pathKey := strings.Join(appendPath(path, k), ".")
+		switch options.Strategy.ListStrategy[pathKey] {
+		case ListStrategyMerge:
+			if mergeKey := options.Strategy.ListMergeKeys[pathKey]; mergeKey != "" {
+				dest, _ := m[k].([]any)
+				m[k] = mergeListByKey(dest, source, appendPath(path, k), mergeKey, options)
+				return
+			}
+		case ListStrategyMergeSet:
+			dest, _ := m[k].([]any)
+			m[k] = mergeListAsSet(dest, source)
+			return
+		}
+	}
+	if mayOverwrite(m, k, source, options) {
+		m[k] = deepCopy(source)
+	}
+}
+
+// mergeListByKey merges source into dest element-wise, matching Mapping
+// elements by mergeKey. Elements present in dest but not source are kept;
+// elements present in both are merged via the path-aware mergeInto, so a
+// Strategy configured for a path nested under listPath (for example
+// "containers.ports") and $patch directives inside a matched element both
+// still apply; new source elements are appended; and a source element
+// shaped like {"$patch": "delete", mergeKey: value} removes the matching
+// dest element.
+func mergeListByKey(dest, source []any, listPath []string, mergeKey string, options MergeOptions) []any {
+	result := make([]any, len(dest))
+	copy(result, dest)
+
+	indexOf := func(key any) int {
+		if mergeKey == "" {
+			return -1
+		}
+		for i, item := range result {
+			if em, ok := item.(Mapping); ok && em[mergeKey] == key {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, item := range source {
+		em, ok := item.(Mapping)
+		if !ok {
+			result = append(result, deepCopy(item))
+			continue
+		}
+
+		if directive, _ := em[patchDirectiveKey].(string); directive == patchDelete {
+			if i := indexOf(em[mergeKey]); i >= 0 {
+				result = append(result[:i], result[i+1:]...)
+			}
+			continue
+		}
+
+		em = withoutPatchKey(em)
+		if i := indexOf(em[mergeKey]); i >= 0 {
+			if existing, ok := result[i].(Mapping); ok {
+				merged := existing.Dup()
+				elementOptions := options
+				elementOptions.Overwrite = true
+				merged.mergeInto(em, listPath, elementOptions)
+				result[i] = merged
+				continue
+			}
+		}
+		result = append(result, em.Dup())
+	}
+
+	return result
+}
+
+// mergeListAsSet returns the union of dest and source with duplicates
+// removed, preserving dest's order and appending new source values in
+// source order.
+func mergeListAsSet(dest, source []any) []any {
+	result := make([]any, 0, len(dest)+len(source))
+	seen := make([]any, 0, len(dest)+len(source))
+
+	contains := func(v any) bool {
+		for _, s := range seen {
+			if reflect.DeepEqual(s, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, v := range dest {
+		if !contains(v) {
+			seen = append(seen, v)
+			result = append(result, deepCopy(v))
+		}
+	}
+	for _, v := range source {
+		if !contains(v) {
+			seen = append(seen, v)
+			result = append(result, deepCopy(v))
+		}
+	}
+	return result
+}
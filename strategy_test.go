@@ -0,0 +1,168 @@
+package dig_test
+
+import (
+	"testing"
+
+	"github.com/k0sproject/dig"
+)
+
+func TestMergeWithStrategy(t *testing.T) {
+	strategy := dig.Strategy{
+		ListMergeKeys: map[string]string{
+			"containers": "name",
+		},
+		ListStrategy: map[string]dig.ListStrategy{
+			"containers": dig.ListStrategyMerge,
+			"tags":       dig.ListStrategyMergeSet,
+		},
+	}
+
+	t.Run("merge by key updates matching elements and appends new ones", func(t *testing.T) {
+		m := dig.Mapping{
+			"containers": []any{
+				dig.Mapping{"name": "app", "image": "v1"},
+				dig.Mapping{"name": "sidecar", "image": "v1"},
+			},
+		}
+		other := dig.Mapping{
+			"containers": []any{
+				dig.Mapping{"name": "app", "image": "v2"},
+				dig.Mapping{"name": "extra", "image": "v1"},
+			},
+		}
+		m.Merge(other, dig.WithStrategy(strategy))
+
+		containers := m.Dig("containers").([]any)
+		mustEqual(t, 3, len(containers))
+		mustEqualString(t, "v2", containers[0].(dig.Mapping)["image"].(string))
+		mustEqualString(t, "v1", containers[1].(dig.Mapping)["image"].(string))
+		mustEqualString(t, "extra", containers[2].(dig.Mapping)["name"].(string))
+	})
+
+	t.Run("merge set deduplicates scalars", func(t *testing.T) {
+		m := dig.Mapping{
+			"tags": []any{"a", "b"},
+		}
+		other := dig.Mapping{
+			"tags": []any{"b", "c"},
+		}
+		m.Merge(other, dig.WithStrategy(strategy))
+
+		tags := m.Dig("tags").([]any)
+		mustEqual(t, 3, len(tags))
+	})
+
+	t.Run("path without a configured strategy falls back to replace", func(t *testing.T) {
+		m := dig.Mapping{
+			"other": []any{"a"},
+		}
+		other := dig.Mapping{
+			"other": []any{"b"},
+		}
+		m.Merge(other, dig.WithStrategy(strategy), dig.WithOverwrite())
+
+		otherList := m.Dig("other").([]any)
+		mustEqual(t, 1, len(otherList))
+		mustEqualString(t, "b", otherList[0].(string))
+	})
+
+	t.Run("strategy for a path nested under a matched element still applies", func(t *testing.T) {
+		nested := dig.Strategy{
+			ListMergeKeys: map[string]string{
+				"containers":       "name",
+				"containers.ports": "name",
+			},
+			ListStrategy: map[string]dig.ListStrategy{
+				"containers":       dig.ListStrategyMerge,
+				"containers.ports": dig.ListStrategyMerge,
+			},
+		}
+		m := dig.Mapping{
+			"containers": []any{
+				dig.Mapping{
+					"name": "app",
+					"ports": []any{
+						dig.Mapping{"name": "http", "port": 80},
+					},
+				},
+			},
+		}
+		other := dig.Mapping{
+			"containers": []any{
+				dig.Mapping{
+					"name": "app",
+					"ports": []any{
+						dig.Mapping{"name": "https", "port": 443},
+					},
+				},
+			},
+		}
+		m.Merge(other, dig.WithStrategy(nested))
+
+		containers := m.Dig("containers").([]any)
+		mustEqual(t, 1, len(containers))
+		ports := containers[0].(dig.Mapping)["ports"].([]any)
+		mustEqual(t, 2, len(ports))
+		mustEqualString(t, "http", ports[0].(dig.Mapping)["name"].(string))
+		mustEqualString(t, "https", ports[1].(dig.Mapping)["name"].(string))
+	})
+
+	t.Run("patch delete on a list element removes the matching container", func(t *testing.T) {
+		m := dig.Mapping{
+			"containers": []any{
+				dig.Mapping{"name": "app", "image": "v1"},
+				dig.Mapping{"name": "sidecar", "image": "v1"},
+			},
+		}
+		other := dig.Mapping{
+			"containers": []any{
+				dig.Mapping{"$patch": "delete", "name": "sidecar"},
+			},
+		}
+		m.Merge(other, dig.WithStrategy(strategy))
+
+		containers := m.Dig("containers").([]any)
+		mustEqual(t, 1, len(containers))
+		mustEqualString(t, "app", containers[0].(dig.Mapping)["name"].(string))
+	})
+}
+
+func TestMergePatchDirectives(t *testing.T) {
+	t.Run("$patch replace forces a sub-tree replace instead of a deep merge", func(t *testing.T) {
+		m := dig.Mapping{
+			"nested": dig.Mapping{
+				"foo": "bar",
+				"baz": "qux",
+			},
+		}
+		other := dig.Mapping{
+			"nested": dig.Mapping{
+				"$patch": "replace",
+				"foo":    "new",
+			},
+		}
+		m.Merge(other, dig.WithStrategy(dig.Strategy{}))
+
+		nested := m.Dig("nested").(dig.Mapping)
+		mustEqual(t, 1, len(nested))
+		mustEqualString(t, "new", nested["foo"].(string))
+	})
+
+	t.Run("$patch delete removes the destination key", func(t *testing.T) {
+		m := dig.Mapping{
+			"foo": "bar",
+			"nested": dig.Mapping{
+				"a": "b",
+			},
+		}
+		other := dig.Mapping{
+			"nested": dig.Mapping{
+				"$patch": "delete",
+			},
+		}
+		m.Merge(other, dig.WithStrategy(dig.Strategy{}))
+
+		mustEqual(t, false, m.HasKey("nested"))
+		mustEqualString(t, "bar", m.DigString("foo"))
+	})
+}
@@ -0,0 +1,328 @@
+package dig
+
+import (
+	"strconv"
+	"time"
+)
+
+// DigInt is like Dig but returns the value as an int. It returns 0 if the
+// path does not exist or the value can't be represented as an int.
+func (m *Mapping) DigInt(keys ...string) int {
+	v, _ := m.TryDigInt(keys...)
+	return v
+}
+
+// TryDigInt is like DigInt but also returns whether the path existed and
+// held a value that could be represented as an int.
+func (m *Mapping) TryDigInt(keys ...string) (int, bool) {
+	return toInt(m.Dig(keys...))
+}
+
+// DigInt64 is like Dig but returns the value as an int64. It returns 0 if
+// the path does not exist or the value can't be represented as an int64.
+func (m *Mapping) DigInt64(keys ...string) int64 {
+	v, _ := m.TryDigInt64(keys...)
+	return v
+}
+
+// TryDigInt64 is like DigInt64 but also returns whether the path existed
+// and held a value that could be represented as an int64.
+func (m *Mapping) TryDigInt64(keys ...string) (int64, bool) {
+	return toInt64(m.Dig(keys...))
+}
+
+// DigBool is like Dig but returns the value as a bool. It returns false if
+// the path does not exist or the value can't be represented as a bool.
+func (m *Mapping) DigBool(keys ...string) bool {
+	v, _ := m.TryDigBool(keys...)
+	return v
+}
+
+// TryDigBool is like DigBool but also returns whether the path existed and
+// held a value that could be represented as a bool.
+func (m *Mapping) TryDigBool(keys ...string) (bool, bool) {
+	return toBool(m.Dig(keys...))
+}
+
+// DigFloat64 is like Dig but returns the value as a float64. It returns 0
+// if the path does not exist or the value can't be represented as a
+// float64.
+func (m *Mapping) DigFloat64(keys ...string) float64 {
+	v, _ := m.TryDigFloat64(keys...)
+	return v
+}
+
+// TryDigFloat64 is like DigFloat64 but also returns whether the path
+// existed and held a value that could be represented as a float64.
+func (m *Mapping) TryDigFloat64(keys ...string) (float64, bool) {
+	return toFloat64(m.Dig(keys...))
+}
+
+// DigDuration is like Dig but returns the value as a time.Duration. Besides
+// a time.Duration, it accepts a string parseable by time.ParseDuration (for
+// example "10s") and numeric values, which are interpreted as nanoseconds.
+// It returns 0 if the path does not exist or the value can't be converted.
+func (m *Mapping) DigDuration(keys ...string) time.Duration {
+	v, _ := m.TryDigDuration(keys...)
+	return v
+}
+
+// TryDigDuration is like DigDuration but also returns whether the path
+// existed and held a value that could be converted to a time.Duration.
+func (m *Mapping) TryDigDuration(keys ...string) (time.Duration, bool) {
+	return toDuration(m.Dig(keys...))
+}
+
+// DigTime is like Dig but returns the value as a time.Time. Besides a
+// time.Time, it accepts strings in time.RFC3339 (or time.RFC3339Nano) and
+// falls back to a handful of other common layouts. It returns the zero
+// time.Time if the path does not exist or the value can't be converted.
+func (m *Mapping) DigTime(keys ...string) time.Time {
+	v, _ := m.TryDigTime(keys...)
+	return v
+}
+
+// TryDigTime is like DigTime but also returns whether the path existed and
+// held a value that could be converted to a time.Time.
+func (m *Mapping) TryDigTime(keys ...string) (time.Time, bool) {
+	return toTime(m.Dig(keys...))
+}
+
+// DigIntSlice is like Dig but returns the value as a []int. It returns nil
+// if the path does not exist or the value can't be represented as a
+// []int.
+func (m *Mapping) DigIntSlice(keys ...string) []int {
+	v, _ := m.TryDigIntSlice(keys...)
+	return v
+}
+
+// TryDigIntSlice is like DigIntSlice but also returns whether the path
+// existed and held a value that could be represented as a []int.
+func (m *Mapping) TryDigIntSlice(keys ...string) ([]int, bool) {
+	return toIntSlice(m.Dig(keys...))
+}
+
+// DigStringSlice is like Dig but returns the value as a []string. It
+// returns nil if the path does not exist or the value can't be
+// represented as a []string.
+func (m *Mapping) DigStringSlice(keys ...string) []string {
+	v, _ := m.TryDigStringSlice(keys...)
+	return v
+}
+
+// TryDigStringSlice is like DigStringSlice but also returns whether the
+// path existed and held a value that could be represented as a
+// []string.
+func (m *Mapping) TryDigStringSlice(keys ...string) ([]string, bool) {
+	return toStringSlice(m.Dig(keys...))
+}
+
+// DigStringMap is like Dig but returns the value as a map[string]any. It
+// returns nil if the path does not exist or the value is not a mapping.
+func (m *Mapping) DigStringMap(keys ...string) map[string]any {
+	v, _ := m.TryDigStringMap(keys...)
+	return v
+}
+
+// TryDigStringMap is like DigStringMap but also returns whether the path
+// existed and held a mapping.
+func (m *Mapping) TryDigStringMap(keys ...string) (map[string]any, bool) {
+	return toStringMap(m.Dig(keys...))
+}
+
+// DigStringMapString is like Dig but returns the value as a
+// map[string]string. It returns nil if the path does not exist or the
+// value is not a mapping whose values can all be represented as strings.
+func (m *Mapping) DigStringMapString(keys ...string) map[string]string {
+	v, _ := m.TryDigStringMapString(keys...)
+	return v
+}
+
+// TryDigStringMapString is like DigStringMapString but also returns
+// whether the path existed and held a value that could be represented as
+// a map[string]string.
+func (m *Mapping) TryDigStringMapString(keys ...string) (map[string]string, bool) {
+	return toStringMapString(m.Dig(keys...))
+}
+
+func toInt(v any) (int, bool) {
+	switch v := v.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float32:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func toInt64(v any) (int64, bool) {
+	i, ok := toInt(v)
+	if !ok {
+		return 0, false
+	}
+	return int64(i), true
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+	default:
+		if i, ok := toInt64(v); ok {
+			return float64(i), true
+		}
+	}
+	return 0, false
+}
+
+func toBool(v any) (bool, bool) {
+	switch v := v.(type) {
+	case bool:
+		return v, true
+	case string:
+		switch v {
+		case "1", "t", "T", "true", "TRUE", "True":
+			return true, true
+		case "0", "f", "F", "false", "FALSE", "False":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func toDuration(v any) (time.Duration, bool) {
+	switch v := v.(type) {
+	case time.Duration:
+		return v, true
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, false
+		}
+		return d, true
+	default:
+		if i, ok := toInt64(v); ok {
+			return time.Duration(i), true
+		}
+	}
+	return 0, false
+}
+
+// timeLayouts are attempted in order when converting a string to a
+// time.Time.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func toTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func toIntSlice(v any) ([]int, bool) {
+	switch v := v.(type) {
+	case []int:
+		return v, true
+	case []any:
+		result := make([]int, len(v))
+		for i, item := range v {
+			iv, ok := toInt(item)
+			if !ok {
+				return nil, false
+			}
+			result[i] = iv
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+func toStringSlice(v any) ([]string, bool) {
+	switch v := v.(type) {
+	case []string:
+		return v, true
+	case []any:
+		result := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			result[i] = s
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+func toStringMap(v any) (map[string]any, bool) {
+	switch v := v.(type) {
+	case Mapping:
+		return v, true
+	case map[string]any:
+		return v, true
+	}
+	return nil, false
+}
+
+func toStringMapString(v any) (map[string]string, bool) {
+	m, ok := toStringMap(v)
+	if !ok {
+		return nil, false
+	}
+	result := make(map[string]string, len(m))
+	for k, val := range m {
+		s, ok := val.(string)
+		if !ok {
+			return nil, false
+		}
+		result[k] = s
+	}
+	return result, true
+}
@@ -0,0 +1,199 @@
+package dig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k0sproject/dig"
+)
+
+func TestDigInt(t *testing.T) {
+	m := dig.Mapping{
+		"int":    42,
+		"float":  float64(42),
+		"string": "not-a-number",
+	}
+
+	t.Run("int value", func(t *testing.T) {
+		mustEqual(t, 42, m.DigInt("int"))
+	})
+
+	t.Run("float value", func(t *testing.T) {
+		mustEqual(t, 42, m.DigInt("float"))
+	})
+
+	t.Run("non-existing key returns zero value", func(t *testing.T) {
+		mustEqual(t, 0, m.DigInt("missing"))
+	})
+
+	t.Run("non-numeric value returns zero value", func(t *testing.T) {
+		mustEqual(t, 0, m.DigInt("string"))
+	})
+
+	t.Run("numeric string value", func(t *testing.T) {
+		numeric := dig.Mapping{"v": "7"}
+		mustEqual(t, 7, numeric.DigInt("v"))
+	})
+
+	t.Run("numeric string with a trailing non-numeric suffix returns zero value", func(t *testing.T) {
+		malformed := dig.Mapping{"v": "3.5don'tcare"}
+		mustEqual(t, 0, malformed.DigInt("v"))
+	})
+
+	t.Run("TryDigInt reports existence", func(t *testing.T) {
+		v, ok := m.TryDigInt("int")
+		mustEqual(t, true, ok)
+		mustEqual(t, 42, v)
+
+		_, ok = m.TryDigInt("missing")
+		mustEqual(t, false, ok)
+	})
+}
+
+func TestDigBool(t *testing.T) {
+	m := dig.Mapping{
+		"bool":   true,
+		"string": "true",
+	}
+
+	t.Run("bool value", func(t *testing.T) {
+		mustEqual(t, true, m.DigBool("bool"))
+	})
+
+	t.Run("string value", func(t *testing.T) {
+		mustEqual(t, true, m.DigBool("string"))
+	})
+
+	t.Run("non-existing key returns false", func(t *testing.T) {
+		mustEqual(t, false, m.DigBool("missing"))
+	})
+}
+
+func TestDigFloat64(t *testing.T) {
+	m := dig.Mapping{
+		"float": 0.5,
+		"int":   1,
+	}
+
+	t.Run("float value", func(t *testing.T) {
+		mustEqual(t, 0.5, m.DigFloat64("float"))
+	})
+
+	t.Run("int value", func(t *testing.T) {
+		mustEqual(t, float64(1), m.DigFloat64("int"))
+	})
+
+	t.Run("non-existing key returns zero value", func(t *testing.T) {
+		mustEqual(t, float64(0), m.DigFloat64("missing"))
+	})
+
+	t.Run("numeric string value", func(t *testing.T) {
+		numeric := dig.Mapping{"v": "1.5"}
+		mustEqual(t, 1.5, numeric.DigFloat64("v"))
+	})
+
+	t.Run("numeric string with a trailing non-numeric suffix returns zero value", func(t *testing.T) {
+		malformed := dig.Mapping{"v": "1.5don'tcare"}
+		mustEqual(t, float64(0), malformed.DigFloat64("v"))
+	})
+}
+
+func TestDigDuration(t *testing.T) {
+	m := dig.Mapping{
+		"string":   "10s",
+		"duration": 10 * time.Second,
+		"nanos":    int64(10),
+	}
+
+	t.Run("string value", func(t *testing.T) {
+		mustEqual(t, 10*time.Second, m.DigDuration("string"))
+	})
+
+	t.Run("duration value", func(t *testing.T) {
+		mustEqual(t, 10*time.Second, m.DigDuration("duration"))
+	})
+
+	t.Run("numeric value is nanoseconds", func(t *testing.T) {
+		mustEqual(t, time.Duration(10), m.DigDuration("nanos"))
+	})
+
+	t.Run("non-existing key returns zero value", func(t *testing.T) {
+		mustEqual(t, time.Duration(0), m.DigDuration("missing"))
+	})
+}
+
+func TestDigTime(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := dig.Mapping{
+		"string": ts.Format(time.RFC3339),
+		"time":   ts,
+	}
+
+	t.Run("string value", func(t *testing.T) {
+		v := m.DigTime("string")
+		mustEqual(t, true, v.Equal(ts))
+	})
+
+	t.Run("time value", func(t *testing.T) {
+		v := m.DigTime("time")
+		mustEqual(t, true, v.Equal(ts))
+	})
+
+	t.Run("non-existing key returns zero value", func(t *testing.T) {
+		mustEqual(t, true, m.DigTime("missing").IsZero())
+	})
+}
+
+func TestDigStringSlice(t *testing.T) {
+	m := dig.Mapping{
+		"strings": []string{"a", "b"},
+		"any":     []any{"a", "b"},
+		"mixed":   []any{"a", 1},
+	}
+
+	t.Run("[]string value", func(t *testing.T) {
+		mustEqual(t, 2, len(m.DigStringSlice("strings")))
+	})
+
+	t.Run("[]any value", func(t *testing.T) {
+		v := m.DigStringSlice("any")
+		mustEqual(t, 2, len(v))
+		mustEqualString(t, "a", v[0])
+	})
+
+	t.Run("[]any with non-string value returns nil", func(t *testing.T) {
+		if v := m.DigStringSlice("mixed"); v != nil {
+			t.Errorf("Expected nil, got %v", v)
+		}
+	})
+
+	t.Run("non-existing key returns nil", func(t *testing.T) {
+		if v := m.DigStringSlice("missing"); v != nil {
+			t.Errorf("Expected nil, got %v", v)
+		}
+	})
+}
+
+func TestDigStringMapString(t *testing.T) {
+	m := dig.Mapping{
+		"mapping": dig.Mapping{"foo": "bar"},
+		"mixed":   dig.Mapping{"foo": 1},
+	}
+
+	t.Run("mapping value", func(t *testing.T) {
+		v := m.DigStringMapString("mapping")
+		mustEqualString(t, "bar", v["foo"])
+	})
+
+	t.Run("mapping with non-string value returns nil", func(t *testing.T) {
+		if v := m.DigStringMapString("mixed"); v != nil {
+			t.Errorf("Expected nil, got %v", v)
+		}
+	})
+
+	t.Run("non-existing key returns nil", func(t *testing.T) {
+		if v := m.DigStringMapString("missing"); v != nil {
+			t.Errorf("Expected nil, got %v", v)
+		}
+	})
+}
@@ -0,0 +1,301 @@
+package dig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DecodeHookFunc is a user-supplied conversion function used by Unmarshal
+// and UnmarshalKey to convert a value to a type reflection can't coerce on
+// its own, for example time.Duration, time.Time or net.IP. It is called
+// with the type of the source value, the destination type, and the value
+// itself. A hook that does not apply to the given types should return v
+// unchanged.
+type DecodeHookFunc func(from, to reflect.Type, v any) (any, error)
+
+// UnmarshalOptions configure Unmarshal and UnmarshalKey.
+type UnmarshalOptions struct {
+	// TagName is the struct tag used to match Mapping keys to struct
+	// fields. Defaults to "dig".
+	TagName string
+	// Strict causes Unmarshal to return an error when the source
+	// Mapping contains keys that do not match any field in the
+	// destination struct.
+	Strict bool
+	// DecodeHooks are tried, in order, before the default conversion
+	// rules for every leaf value.
+	DecodeHooks []DecodeHookFunc
+}
+
+// UnmarshalOption is used to configure Unmarshal and UnmarshalKey.
+type UnmarshalOption func(*UnmarshalOptions)
+
+// WithTagName sets the struct tag name used to match Mapping keys to
+// struct fields. The default is "dig".
+func WithTagName(name string) UnmarshalOption {
+	return func(o *UnmarshalOptions) {
+		o.TagName = name
+	}
+}
+
+// WithStrict causes Unmarshal to return an error when the source Mapping
+// contains a key that does not match any field of the destination
+// struct.
+func WithStrict() UnmarshalOption {
+	return func(o *UnmarshalOptions) {
+		o.Strict = true
+	}
+}
+
+// WithDecodeHooks registers one or more DecodeHookFuncs that are given a
+// chance to convert a leaf value before the default conversion rules are
+// applied.
+func WithDecodeHooks(hooks ...DecodeHookFunc) UnmarshalOption {
+	return func(o *UnmarshalOptions) {
+		o.DecodeHooks = append(o.DecodeHooks, hooks...)
+	}
+}
+
+// Unmarshal decodes the Mapping into out, which must be a non-nil pointer
+// to a struct, map or slice. Struct fields are matched to Mapping keys
+// using the tag configured with WithTagName (by default "dig"), falling
+// back to the "json" and "yaml" tags and finally a case-insensitive
+// comparison of the field name.
+func (m Mapping) Unmarshal(out any, opts ...UnmarshalOption) error {
+	options := UnmarshalOptions{TagName: "dig"}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("dig: Unmarshal target must be a non-nil pointer, got %T", out)
+	}
+
+	return decodeValue(m, rv.Elem(), &options)
+}
+
+// UnmarshalKey is like Unmarshal but first digs into m using keys and
+// decodes the value found there instead of the whole Mapping.
+func (m Mapping) UnmarshalKey(keys []string, out any, opts ...UnmarshalOption) error {
+	v := m.Dig(keys...)
+	if v == nil {
+		return nil
+	}
+
+	options := UnmarshalOptions{TagName: "dig"}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("dig: UnmarshalKey target must be a non-nil pointer, got %T", out)
+	}
+
+	return decodeValue(v, rv.Elem(), &options)
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// decodeValue decodes v into target, applying decode hooks and then the
+// default conversion rules based on target's kind.
+func decodeValue(v any, target reflect.Value, options *UnmarshalOptions) error {
+	for _, hook := range options.DecodeHooks {
+		converted, err := hook(reflect.TypeOf(v), target.Type(), v)
+		if err != nil {
+			return fmt.Errorf("dig: decode hook failed: %w", err)
+		}
+		v = converted
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if vv := reflect.ValueOf(v); vv.Type().AssignableTo(target.Type()) {
+		target.Set(vv)
+		return nil
+	}
+
+	if target.Type() == durationType {
+		d, ok := toDuration(v)
+		if !ok {
+			return fmt.Errorf("dig: cannot decode %T into time.Duration", v)
+		}
+		target.Set(reflect.ValueOf(d))
+		return nil
+	}
+	if target.Type() == timeType {
+		t, ok := toTime(v)
+		if !ok {
+			return fmt.Errorf("dig: cannot decode %T into time.Time", v)
+		}
+		target.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return decodeValue(v, target.Elem(), options)
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		return decodeStruct(v, target, options)
+	case reflect.Map:
+		return decodeMap(v, target, options)
+	case reflect.Slice:
+		return decodeSlice(v, target, options)
+	case reflect.String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("dig: cannot decode %T into string", v)
+		}
+		target.SetString(s)
+	case reflect.Bool:
+		b, ok := toBool(v)
+		if !ok {
+			return fmt.Errorf("dig: cannot decode %T into bool", v)
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := toInt64(v)
+		if !ok {
+			return fmt.Errorf("dig: cannot decode %T into %s", v, target.Kind())
+		}
+		target.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := toInt64(v)
+		if !ok {
+			return fmt.Errorf("dig: cannot decode %T into %s", v, target.Kind())
+		}
+		target.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat64(v)
+		if !ok {
+			return fmt.Errorf("dig: cannot decode %T into %s", v, target.Kind())
+		}
+		target.SetFloat(f)
+	case reflect.Interface:
+		target.Set(reflect.ValueOf(v))
+	default:
+		return fmt.Errorf("dig: unsupported decode target kind %s", target.Kind())
+	}
+	return nil
+}
+
+// fieldKey returns the Mapping key a struct field decodes from, honoring
+// TagName, then falling back to json/yaml tags and the field name. ok is
+// false if the field is explicitly skipped via `tag:"-"`.
+func fieldKey(field reflect.StructField, tagName string) (key string, ok bool) {
+	for _, tag := range []string{tagName, "json", "yaml"} {
+		value, present := field.Tag.Lookup(tag)
+		if !present {
+			continue
+		}
+		name := strings.Split(value, ",")[0]
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	return field.Name, true
+}
+
+func decodeStruct(v any, target reflect.Value, options *UnmarshalOptions) error {
+	source, ok := toStringMap(v)
+	if !ok {
+		return fmt.Errorf("dig: cannot decode %T into struct %s", v, target.Type())
+	}
+
+	used := make(map[string]bool, len(source))
+	t := target.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue // unexported
+		}
+
+		key, ok := fieldKey(field, options.TagName)
+		if !ok {
+			continue
+		}
+
+		value, present := source[key]
+		if !present {
+			for sk, sv := range source {
+				if strings.EqualFold(sk, key) {
+					value, present = sv, true
+					key = sk
+					break
+				}
+			}
+		}
+		if !present {
+			continue
+		}
+
+		used[key] = true
+		if err := decodeValue(value, target.Field(i), options); err != nil {
+			return fmt.Errorf("dig: field %q: %w", field.Name, err)
+		}
+	}
+
+	if options.Strict {
+		for k := range source {
+			if !used[k] {
+				return fmt.Errorf("dig: unknown key %q for struct %s", k, t)
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodeMap(v any, target reflect.Value, options *UnmarshalOptions) error {
+	source, ok := toStringMap(v)
+	if !ok {
+		return fmt.Errorf("dig: cannot decode %T into %s", v, target.Type())
+	}
+
+	result := reflect.MakeMapWithSize(target.Type(), len(source))
+	elemType := target.Type().Elem()
+	for k, sv := range source {
+		elem := reflect.New(elemType).Elem()
+		if err := decodeValue(sv, elem, options); err != nil {
+			return fmt.Errorf("dig: key %q: %w", k, err)
+		}
+		result.SetMapIndex(reflect.ValueOf(k), elem)
+	}
+	target.Set(result)
+	return nil
+}
+
+func decodeSlice(v any, target reflect.Value, options *UnmarshalOptions) error {
+	source, ok := v.([]any)
+	if !ok {
+		return fmt.Errorf("dig: cannot decode %T into %s", v, target.Type())
+	}
+
+	elemType := target.Type().Elem()
+	result := reflect.MakeSlice(target.Type(), len(source), len(source))
+	for i, sv := range source {
+		elem := reflect.New(elemType).Elem()
+		if err := decodeValue(sv, elem, options); err != nil {
+			return fmt.Errorf("dig: index %d: %w", i, err)
+		}
+		result.Index(i).Set(elem)
+	}
+	target.Set(result)
+	return nil
+}
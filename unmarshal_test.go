@@ -0,0 +1,134 @@
+package dig_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/dig"
+)
+
+type unmarshalTarget struct {
+	Name    string
+	Port    int  `dig:"port"`
+	Enabled bool `json:"enabled"`
+	Timeout time.Duration
+	Tags    []string
+	Extra   unmarshalNested
+}
+
+type unmarshalNested struct {
+	Foo string
+}
+
+func TestUnmarshal(t *testing.T) {
+	m := dig.Mapping{
+		"name":    "server",
+		"port":    8080,
+		"enabled": true,
+		"timeout": "5s",
+		"tags":    []any{"a", "b"},
+		"extra": dig.Mapping{
+			"foo": "bar",
+		},
+	}
+
+	var out unmarshalTarget
+	if err := m.Unmarshal(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mustEqualString(t, "server", out.Name)
+	mustEqual(t, 8080, out.Port)
+	mustEqual(t, true, out.Enabled)
+	mustEqual(t, 5*time.Second, out.Timeout)
+	mustEqual(t, true, reflect.DeepEqual([]string{"a", "b"}, out.Tags))
+	mustEqualString(t, "bar", out.Extra.Foo)
+}
+
+func TestUnmarshalCaseInsensitiveFallback(t *testing.T) {
+	m := dig.Mapping{
+		"Name": "server",
+	}
+
+	var out unmarshalTarget
+	if err := m.Unmarshal(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mustEqualString(t, "server", out.Name)
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	m := dig.Mapping{
+		"name":    "server",
+		"unknown": "value",
+	}
+
+	var out unmarshalTarget
+	err := m.Unmarshal(&out, dig.WithStrict())
+	if err == nil {
+		t.Fatal("expected an error for an unknown key in strict mode")
+	}
+}
+
+func TestUnmarshalKey(t *testing.T) {
+	m := dig.Mapping{
+		"server": dig.Mapping{
+			"name": "server",
+			"port": 8080,
+		},
+	}
+
+	var out unmarshalTarget
+	if err := m.UnmarshalKey([]string{"server"}, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mustEqualString(t, "server", out.Name)
+	mustEqual(t, 8080, out.Port)
+}
+
+func TestUnmarshalWithDecodeHooks(t *testing.T) {
+	type target struct {
+		IP net.IP
+	}
+
+	m := dig.Mapping{
+		"ip": "127.0.0.1",
+	}
+
+	ipHook := func(from, to reflect.Type, v any) (any, error) {
+		if to != reflect.TypeOf(net.IP{}) {
+			return v, nil
+		}
+		s, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+		return net.ParseIP(s), nil
+	}
+
+	var out target
+	if err := m.Unmarshal(&out, dig.WithDecodeHooks(ipHook)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.IP.String() != "127.0.0.1" {
+		t.Errorf("Expected 127.0.0.1, got %v", out.IP)
+	}
+}
+
+func TestUnmarshalYAMLTagFallback(t *testing.T) {
+	type target struct {
+		Name string `yaml:"custom_name"`
+	}
+
+	m := dig.Mapping{
+		"custom_name": "hello",
+	}
+
+	var out target
+	if err := m.Unmarshal(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mustEqualString(t, "hello", out.Name)
+}